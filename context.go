@@ -0,0 +1,178 @@
+package scientist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrCandidateTimeout is recorded as an Observation's Err when a
+// candidate behavior exceeds its Experiment.CandidateTimeout deadline.
+var ErrCandidateTimeout = errors.New("scientist: candidate timed out")
+
+// RunContext behaves like Run, but drives behaviors registered via
+// UseCtx/TryCtx and threads ctx through them. Candidates, but not the
+// control, are additionally bounded by Experiment.CandidateTimeout: a
+// candidate is run in its own goroutine, and if it has not completed by
+// the deadline, RunContext stops waiting on it, records Runtime as the
+// time elapsed up to the deadline, sets Observation.Err to
+// ErrCandidateTimeout, and counts it as mismatched unless an ignorer
+// says otherwise. This bounds a candidate even if its behavior never
+// observes ctx.Done(); the abandoned goroutine is left to finish (or
+// leak) on its own, since Go has no way to forcibly stop it.
+//
+// If ctx is already done before the control runs, RunContext returns
+// early with a Result whose Errors holds a single "before_run" entry.
+func RunContext(ctx context.Context, e *Experiment, name string) Result {
+	r := Result{Experiment: e}
+
+	if err := ctx.Err(); err != nil {
+		r.Errors = append(r.Errors, e.resultErr("before_run", err))
+		return r
+	}
+
+	if err := e.beforeRun(); err != nil {
+		r.Errors = append(r.Errors, e.resultErr("before_run", err))
+	}
+
+	r.Control = observeCtx(ctx, e, name, e.ctxBehaviors[name])
+	runCandidates := e.shouldRunCandidates(&r)
+
+	candidateNames := make([]string, 0, max(len(e.ctxBehaviors)-1, 0))
+	if runCandidates {
+		for bname := range e.ctxBehaviors {
+			if bname != name {
+				candidateNames = append(candidateNames, bname)
+			}
+		}
+		sort.Strings(candidateNames)
+	}
+
+	r.Candidates = make([]*Observation, len(candidateNames))
+	r.Ignored = make([]*Observation, 0, len(candidateNames))
+	r.Mismatched = make([]*Observation, 0, len(candidateNames))
+	r.Observations = make([]*Observation, len(candidateNames)+1)
+	r.Observations[0] = r.Control
+	r.Skipped = !runCandidates
+
+	for i, bname := range candidateNames {
+		c := observeCandidateCtx(ctx, e, bname, e.ctxBehaviors[bname], e.candidateTimeout)
+
+		r.Candidates[i] = c
+		r.Observations[i+1] = c
+
+		mismatched, err := mismatching(e, r.Control, c)
+		if err != nil {
+			mismatched = true
+			r.Errors = append(r.Errors, e.resultErr("compare", err))
+		}
+
+		if errors.Is(c.Err, ErrCandidateTimeout) {
+			mismatched = true
+		}
+
+		if !mismatched {
+			continue
+		}
+
+		ignored, err := ignoring(e, r.Control, c)
+		if err != nil {
+			ignored = false
+			r.Errors = append(r.Errors, e.resultErr("ignore", err))
+		}
+
+		if ignored {
+			r.Ignored = append(r.Ignored, c)
+		} else {
+			r.Mismatched = append(r.Mismatched, c)
+		}
+	}
+
+	if err := e.publisher(r); err != nil {
+		r.Errors = append(r.Errors, e.resultErr("publish", err))
+	}
+
+	if len(r.Errors) > 0 {
+		e.errorReporter(r.Errors...)
+	}
+
+	return r
+}
+
+func observeCtx(ctx context.Context, e *Experiment, name string, b behaviorCtxFunc) *Observation {
+	o := &Observation{
+		Experiment: e,
+		Name:       name,
+		Started:    time.Now(),
+	}
+
+	if b == nil {
+		o.Runtime = time.Since(o.Started)
+		o.Err = behaviorNotFound(e, name)
+		return o
+	}
+
+	v, err := runBehaviorCtx(ctx, e, name, b)
+	o.Runtime = time.Since(o.Started)
+	o.Value = v
+	o.Err = err
+	return o
+}
+
+// observeCandidateCtx observes a candidate behavior bounded by timeout
+// (no bound when timeout <= 0). Unlike observeCtx, it does not wait for
+// the behavior to return once the deadline passes: the behavior runs in
+// its own goroutine, and if the deadline wins the race, the returned
+// Observation reports ErrCandidateTimeout with Runtime truncated to the
+// deadline, regardless of whether the behavior itself honors ctx.
+func observeCandidateCtx(ctx context.Context, e *Experiment, name string, b behaviorCtxFunc, timeout time.Duration) *Observation {
+	if timeout <= 0 {
+		return observeCtx(ctx, e, name, b)
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	started := time.Now()
+	done := make(chan *Observation, 1)
+	go func() {
+		done <- observeCtx(cctx, e, name, b)
+	}()
+
+	select {
+	case o := <-done:
+		return o
+	case <-cctx.Done():
+		err := cctx.Err()
+		if err == context.DeadlineExceeded {
+			err = ErrCandidateTimeout
+		}
+		return &Observation{
+			Experiment: e,
+			Name:       name,
+			Started:    started,
+			Runtime:    time.Since(started),
+			Err:        err,
+		}
+	}
+}
+
+func runBehaviorCtx(ctx context.Context, e *Experiment, name string, b behaviorCtxFunc) (value interface{}, err error) {
+	defer func() {
+		if er := recover(); er != nil {
+			value = nil
+			switch t := er.(type) {
+			case string:
+				err = errors.New(t)
+			case error:
+				err = t
+			default:
+				err = fmt.Errorf("%v", t)
+			}
+		}
+	}()
+	value, err = b(ctx)
+	return
+}
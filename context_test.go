@@ -0,0 +1,54 @@
+package scientist
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunContextCandidateTimeout(t *testing.T) {
+	e := New("timeout")
+	e.CandidateTimeout(20 * time.Millisecond)
+	e.UseCtx(func(ctx context.Context) (interface{}, error) {
+		return "control", nil
+	})
+	e.TryCtx(func(ctx context.Context) (interface{}, error) {
+		time.Sleep(150 * time.Millisecond)
+		return "candidate", nil
+	})
+
+	start := time.Now()
+	r := RunContext(context.Background(), e, controlBehavior)
+	elapsed := time.Since(start)
+
+	if elapsed > 100*time.Millisecond {
+		t.Fatalf("RunContext blocked for %s, want it bounded by the candidate timeout", elapsed)
+	}
+
+	if len(r.Candidates) != 1 {
+		t.Fatalf("expected 1 candidate, got %d", len(r.Candidates))
+	}
+
+	c := r.Candidates[0]
+	if !errors.Is(c.Err, ErrCandidateTimeout) {
+		t.Fatalf("expected ErrCandidateTimeout, got %v", c.Err)
+	}
+
+	if len(r.Mismatched) != 1 {
+		t.Fatalf("expected the timed-out candidate to be counted as mismatched, got %d", len(r.Mismatched))
+	}
+}
+
+func TestRunContextBeforeRunCancelled(t *testing.T) {
+	e := New("cancelled")
+	e.UseCtx(func(ctx context.Context) (interface{}, error) { return nil, nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := RunContext(ctx, e, controlBehavior)
+	if len(r.Errors) != 1 || r.Errors[0].Operation != "before_run" {
+		t.Fatalf("expected a single before_run error, got %#v", r.Errors)
+	}
+}
@@ -0,0 +1,182 @@
+package scientist
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"time"
+)
+
+// behaviorFunc is the signature used for the control and every candidate
+// registered on an Experiment via Use/Try.
+type behaviorFunc func() (interface{}, error)
+
+// behaviorCtxFunc is the context-aware counterpart of behaviorFunc, used
+// for behaviors registered via UseCtx/TryCtx and run through RunContext.
+type behaviorCtxFunc func(context.Context) (interface{}, error)
+
+// Experiment describes a control behavior, zero or more candidate
+// behaviors, and the hooks used to compare, clean, publish and report on
+// the observations produced by running them.
+type Experiment struct {
+	Name string
+
+	behaviors    map[string]behaviorFunc
+	ctxBehaviors map[string]behaviorCtxFunc
+	concurrency  int
+
+	candidateTimeout time.Duration
+
+	runIf  func() (bool, error)
+	sample func() (bool, error)
+
+	comparator    func(control, candidate interface{}) (bool, error)
+	ignores       []func(control, candidate interface{}) (bool, error)
+	cleaner       func(interface{}) (interface{}, error)
+	publisher     func(Result) error
+	errorReporter func(...ResultError)
+	beforeRun     func() error
+}
+
+// New creates an Experiment with sane defaults: equality comparison via
+// reflect.DeepEqual, a no-op cleaner, and a no-op publisher.
+func New(name string) *Experiment {
+	return &Experiment{
+		Name:         name,
+		behaviors:    map[string]behaviorFunc{},
+		ctxBehaviors: map[string]behaviorCtxFunc{},
+		comparator: func(control, candidate interface{}) (bool, error) {
+			return reflect.DeepEqual(control, candidate), nil
+		},
+		cleaner: func(v interface{}) (interface{}, error) {
+			return v, nil
+		},
+		publisher:     func(Result) error { return nil },
+		errorReporter: func(...ResultError) {},
+		beforeRun:     func() error { return nil },
+	}
+}
+
+// Use registers the control behavior, i.e. the existing, trusted
+// implementation.
+func (e *Experiment) Use(b behaviorFunc) {
+	e.behaviors[controlBehavior] = b
+}
+
+// Try registers a candidate behavior, i.e. a new implementation being
+// evaluated against the control.
+func (e *Experiment) Try(b behaviorFunc) {
+	e.behaviors[nextName(candidateBehavior, func(n string) bool {
+		_, ok := e.behaviors[n]
+		return ok
+	})] = b
+}
+
+// UseCtx registers the context-aware control behavior, run via
+// RunContext.
+func (e *Experiment) UseCtx(b behaviorCtxFunc) {
+	e.ctxBehaviors[controlBehavior] = b
+}
+
+// TryCtx registers a context-aware candidate behavior, run via
+// RunContext.
+func (e *Experiment) TryCtx(b behaviorCtxFunc) {
+	e.ctxBehaviors[nextName(candidateBehavior, func(n string) bool {
+		_, ok := e.ctxBehaviors[n]
+		return ok
+	})] = b
+}
+
+// nextName finds the first name of the form base, base-2, base-3, ...
+// for which exists returns false, letting Try/TryCtx register more than
+// one candidate without colliding.
+func nextName(base string, exists func(string) bool) string {
+	if !exists(base) {
+		return base
+	}
+
+	for i := 2; ; i++ {
+		n := fmt.Sprintf("%s-%d", base, i)
+		if !exists(n) {
+			return n
+		}
+	}
+}
+
+// Compare sets the function used to determine whether a candidate's
+// value matches the control's.
+func (e *Experiment) Compare(c func(control, candidate interface{}) (bool, error)) {
+	e.comparator = c
+}
+
+// Ignore registers a predicate that, when true, excludes an otherwise
+// mismatched observation from Result.Mismatched.
+func (e *Experiment) Ignore(i func(control, candidate interface{}) (bool, error)) {
+	e.ignores = append(e.ignores, i)
+}
+
+// Clean sets the function used to produce Observation.CleanedValue.
+func (e *Experiment) Clean(c func(interface{}) (interface{}, error)) {
+	e.cleaner = c
+}
+
+// Publish sets the function invoked with the Result once an experiment
+// finishes running.
+func (e *Experiment) Publish(p func(Result) error) {
+	e.publisher = p
+}
+
+// ReportErrors sets the function invoked with any ResultErrors gathered
+// while running the experiment.
+func (e *Experiment) ReportErrors(r func(...ResultError)) {
+	e.errorReporter = r
+}
+
+// BeforeRun sets a function that runs before the control or any
+// candidate is observed; a non-nil error is recorded under the
+// "before_run" operation but does not stop the run.
+func (e *Experiment) BeforeRun(b func() error) {
+	e.beforeRun = b
+}
+
+// Concurrency caps the number of candidate behaviors (plus the control)
+// that RunConcurrent will execute in flight at once. A value <= 0 means
+// unbounded.
+func (e *Experiment) Concurrency(n int) {
+	e.concurrency = n
+}
+
+// CandidateTimeout bounds how long RunContext lets a single candidate's
+// context-aware behavior run before its derived context is cancelled.
+// The control is never subject to this deadline. A value <= 0 disables
+// the timeout.
+func (e *Experiment) CandidateTimeout(d time.Duration) {
+	e.candidateTimeout = d
+}
+
+// RunIf sets a predicate that Run consults before observing any
+// candidate; when it returns false or an error, only the control runs.
+func (e *Experiment) RunIf(f func() (bool, error)) {
+	e.runIf = f
+}
+
+// Sample sets a predicate that Run consults, after RunIf, before
+// observing any candidate; when it returns false or an error, only the
+// control runs. Use this to roll a candidate out to a fraction of live
+// traffic without paying its cost on every request.
+func (e *Experiment) Sample(f func() (bool, error)) {
+	e.sample = f
+}
+
+// SampleRate is a convenience around Sample that runs candidates for a
+// random fraction (0.0-1.0) of calls.
+func (e *Experiment) SampleRate(rate float64) {
+	e.sample = func() (bool, error) {
+		return rand.Float64() < rate, nil
+	}
+}
+
+func (e *Experiment) resultErr(op string, err error) ResultError {
+	return ResultError{Operation: op, Experiment: e.Name, Err: err}
+}
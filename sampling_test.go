@@ -0,0 +1,62 @@
+package scientist
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRunSkipsCandidatesOnRunIfFalse(t *testing.T) {
+	e := New("run-if")
+	e.RunIf(func() (bool, error) { return false, nil })
+	e.Use(func() (interface{}, error) { return 1, nil })
+	e.Try(func() (interface{}, error) { return 2, nil })
+
+	r := Run(e, controlBehavior)
+	if !r.Skipped {
+		t.Fatal("expected Skipped")
+	}
+	if len(r.Candidates) != 0 {
+		t.Fatalf("expected no candidates observed, got %d", len(r.Candidates))
+	}
+}
+
+func TestRunSkipsCandidatesOnSampleError(t *testing.T) {
+	e := New("sample-err")
+	sampleErr := errors.New("boom")
+	e.Sample(func() (bool, error) { return true, sampleErr })
+	e.Use(func() (interface{}, error) { return 1, nil })
+	e.Try(func() (interface{}, error) { return 2, nil })
+
+	r := Run(e, controlBehavior)
+	if !r.Skipped {
+		t.Fatal("expected Skipped")
+	}
+	if len(r.Errors) != 1 || r.Errors[0].Operation != "sample" {
+		t.Fatalf("expected a single sample error, got %#v", r.Errors)
+	}
+}
+
+func TestRunConcurrentSkipsCandidatesOnRunIfFalse(t *testing.T) {
+	e := New("run-if-concurrent")
+	e.RunIf(func() (bool, error) { return false, nil })
+	e.Use(func() (interface{}, error) { return 1, nil })
+	e.Try(func() (interface{}, error) { return 2, nil })
+
+	r := RunConcurrent(e, controlBehavior)
+	if !r.Skipped || len(r.Candidates) != 0 {
+		t.Fatalf("expected skipped run with no candidates, got %#v", r)
+	}
+}
+
+func TestRunContextSkipsCandidatesOnSampleFalse(t *testing.T) {
+	e := New("sample-context")
+	e.SampleRate(0)
+	e.UseCtx(func(ctx context.Context) (interface{}, error) { return 1, nil })
+	e.TryCtx(func(ctx context.Context) (interface{}, error) { return 2, nil })
+
+	r := RunContext(context.Background(), e, controlBehavior)
+	if !r.Skipped || len(r.Candidates) != 0 {
+		t.Fatalf("expected skipped run with no candidates, got %#v", r)
+	}
+}
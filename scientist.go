@@ -3,7 +3,10 @@ package scientist
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"time"
+
+	"golang.org/x/sync/errgroup"
 )
 
 const (
@@ -45,6 +48,10 @@ type Result struct {
 	Ignored      []*Observation
 	Mismatched   []*Observation
 	Errors       []ResultError
+
+	// Skipped is true when the experiment's RunIf or Sample hook decided
+	// the control should run alone, without paying for any candidate.
+	Skipped bool
 }
 
 func Run(e *Experiment, name string) Result {
@@ -53,8 +60,24 @@ func Run(e *Experiment, name string) Result {
 		r.Errors = append(r.Errors, e.resultErr("before_run", err))
 	}
 
-	numCandidates := len(e.behaviors) - 1
 	r.Control = observe(e, name, e.behaviors[name])
+
+	if !e.shouldRunCandidates(&r) {
+		r.Skipped = true
+		r.Observations = []*Observation{r.Control}
+
+		if err := e.publisher(r); err != nil {
+			r.Errors = append(r.Errors, e.resultErr("publish", err))
+		}
+
+		if len(r.Errors) > 0 {
+			e.errorReporter(r.Errors...)
+		}
+
+		return r
+	}
+
+	numCandidates := len(e.behaviors) - 1
 	r.Candidates = make([]*Observation, numCandidates)
 	r.Ignored = make([]*Observation, 0, numCandidates)
 	r.Mismatched = make([]*Observation, 0, numCandidates)
@@ -106,6 +129,129 @@ func Run(e *Experiment, name string) Result {
 	return r
 }
 
+// shouldRunCandidates consults the experiment's RunIf and Sample hooks,
+// in that order, to decide whether candidates should be observed this
+// run. Either hook erroring or declining skips the candidates; errors
+// are recorded on r under the "run_if"/"sample" operations, mirroring
+// the fail-safe posture of ignoring.
+func (e *Experiment) shouldRunCandidates(r *Result) bool {
+	if e.runIf != nil {
+		ok, err := e.runIf()
+		if err != nil {
+			r.Errors = append(r.Errors, e.resultErr("run_if", err))
+			return false
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	if e.sample != nil {
+		ok, err := e.sample()
+		if err != nil {
+			r.Errors = append(r.Errors, e.resultErr("sample", err))
+			return false
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RunConcurrent behaves like Run, except the control and every candidate
+// behavior are observed in parallel via an errgroup instead of
+// sequentially. In-flight goroutines are capped at e.concurrency (see
+// Experiment.Concurrency); a value <= 0 leaves them unbounded.
+//
+// Ordering of r.Candidates is kept deterministic despite the concurrent
+// execution: candidates are assigned to a fixed index derived from their
+// sorted behavior names rather than map-iteration order.
+func RunConcurrent(e *Experiment, name string) Result {
+	r := Result{Experiment: e}
+	if err := e.beforeRun(); err != nil {
+		r.Errors = append(r.Errors, e.resultErr("before_run", err))
+	}
+
+	runCandidates := e.shouldRunCandidates(&r)
+
+	candidateNames := make([]string, 0, max(len(e.behaviors)-1, 0))
+	if runCandidates {
+		for bname := range e.behaviors {
+			if bname != name {
+				candidateNames = append(candidateNames, bname)
+			}
+		}
+		sort.Strings(candidateNames)
+	}
+
+	r.Candidates = make([]*Observation, len(candidateNames))
+	r.Ignored = make([]*Observation, 0, len(candidateNames))
+	r.Mismatched = make([]*Observation, 0, len(candidateNames))
+	r.Observations = make([]*Observation, len(candidateNames)+1)
+
+	var g errgroup.Group
+	if e.concurrency > 0 {
+		g.SetLimit(e.concurrency)
+	}
+
+	g.Go(func() error {
+		r.Control = observe(e, name, e.behaviors[name])
+		return nil
+	})
+
+	for i, bname := range candidateNames {
+		i, bname := i, bname
+		b := e.behaviors[bname]
+		g.Go(func() error {
+			c := observe(e, bname, b)
+			r.Candidates[i] = c
+			r.Observations[i+1] = c
+			return nil
+		})
+	}
+
+	g.Wait()
+
+	for _, c := range r.Candidates {
+		mismatched, err := mismatching(e, r.Control, c)
+		if err != nil {
+			mismatched = true
+			r.Errors = append(r.Errors, e.resultErr("compare", err))
+		}
+
+		if !mismatched {
+			continue
+		}
+
+		ignored, err := ignoring(e, r.Control, c)
+		if err != nil {
+			ignored = false
+			r.Errors = append(r.Errors, e.resultErr("ignore", err))
+		}
+
+		if ignored {
+			r.Ignored = append(r.Ignored, c)
+		} else {
+			r.Mismatched = append(r.Mismatched, c)
+		}
+	}
+
+	r.Observations[0] = r.Control
+	r.Skipped = !runCandidates
+
+	if err := e.publisher(r); err != nil {
+		r.Errors = append(r.Errors, e.resultErr("publish", err))
+	}
+
+	if len(r.Errors) > 0 {
+		e.errorReporter(r.Errors...)
+	}
+
+	return r
+}
+
 func mismatching(e *Experiment, control, candidate *Observation) (bool, error) {
 	matching, err := e.comparator(control.Value, candidate.Value)
 	return !matching, err
@@ -0,0 +1,51 @@
+package scientist
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestRunConcurrentDeterministicOrdering(t *testing.T) {
+	e := New("sorts-candidates")
+	e.Concurrency(2)
+	e.Use(func() (interface{}, error) { return 0, nil })
+	e.Try(func() (interface{}, error) { return 1, nil })
+	e.Try(func() (interface{}, error) { return 2, nil })
+	e.Try(func() (interface{}, error) { return 3, nil })
+
+	names := make([]string, 0, len(e.behaviors)-1)
+	for n := range e.behaviors {
+		if n != controlBehavior {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+
+	for i := 0; i < 20; i++ {
+		r := RunConcurrent(e, controlBehavior)
+		if len(r.Candidates) != len(names) {
+			t.Fatalf("expected %d candidates, got %d", len(names), len(r.Candidates))
+		}
+		for j, want := range names {
+			if r.Candidates[j] == nil || r.Candidates[j].Name != want {
+				t.Fatalf("candidate %d: want %q, got %#v", j, want, r.Candidates[j])
+			}
+		}
+	}
+}
+
+// TestRunConcurrentRace exercises the concurrent writes into
+// r.Control/r.Candidates/r.Observations; run with -race to catch
+// regressions in the fixed-index assignment.
+func TestRunConcurrentRace(t *testing.T) {
+	e := New("race")
+	e.Use(func() (interface{}, error) { return 1, nil })
+	for i := 0; i < 8; i++ {
+		e.Try(func() (interface{}, error) { return 1, nil })
+	}
+
+	r := RunConcurrent(e, controlBehavior)
+	if len(r.Mismatched) != 0 {
+		t.Fatalf("expected no mismatches, got %d", len(r.Mismatched))
+	}
+}